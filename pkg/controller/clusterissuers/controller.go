@@ -3,50 +3,46 @@ package issuers
 import (
 	"context"
 	"fmt"
-	"log"
-	"sync"
 	"time"
 
-	"github.com/golang/glog"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
 
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
 	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
 	cminformers "github.com/jetstack-experimental/cert-manager/pkg/client/informers/certmanager/v1alpha1"
 	cmlisters "github.com/jetstack-experimental/cert-manager/pkg/client/listers/certmanager/v1alpha1"
 	controllerpkg "github.com/jetstack-experimental/cert-manager/pkg/controller"
+	"github.com/jetstack-experimental/cert-manager/pkg/controller/framework"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
 	"github.com/jetstack-experimental/cert-manager/pkg/util"
 )
 
+// Controller reconciles ClusterIssuer resources, re-syncing an issuer
+// whenever a Secret it observes in the cluster resource namespace
+// changes. The work loop itself lives in pkg/controller/framework.
 type Controller struct {
 	client        kubernetes.Interface
 	cmClient      clientset.Interface
 	issuerFactory issuer.Factory
 	recorder      record.EventRecorder
 
-	// To allow injection for testing.
-	syncHandler func(ctx context.Context, key string) error
+	issuerLister  cmlisters.ClusterIssuerLister
+	issuerIndexer cache.Indexer
+	secretLister  corelisters.SecretLister
 
-	issuerInformerSynced cache.InformerSynced
-	issuerLister         cmlisters.ClusterIssuerLister
-
-	secretInformerSynced cache.InformerSynced
-	secretLister         corelisters.SecretLister
-
-	queue                    workqueue.RateLimitingInterface
-	workerWg                 sync.WaitGroup
 	clusterResourceNamespace string
+
+	ctrl framework.Controller
 }
 
 func New(
@@ -58,133 +54,172 @@ func New(
 	recorder record.EventRecorder,
 	clusterResourceNamespace string,
 ) *Controller {
-	ctrl := &Controller{client: cl, cmClient: cmClient, issuerFactory: issuerFactory, recorder: recorder, clusterResourceNamespace: clusterResourceNamespace}
-	ctrl.syncHandler = ctrl.processNextWorkItem
-	ctrl.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "clusterissuers")
+	issuerIndexer := issuersInformer.GetIndexer()
+	if err := issuerIndexer.AddIndexers(cache.Indexers{secretNameIndex: indexIssuersBySecretName}); err != nil {
+		panic(err)
+	}
 
-	issuersInformer.AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: ctrl.queue})
-	ctrl.issuerInformerSynced = issuersInformer.HasSynced
-	ctrl.issuerLister = cmlisters.NewClusterIssuerLister(issuersInformer.GetIndexer())
+	c := &Controller{
+		client:                   cl,
+		cmClient:                 cmClient,
+		issuerFactory:            issuerFactory,
+		recorder:                 recorder,
+		clusterResourceNamespace: clusterResourceNamespace,
+		issuerLister:             cmlisters.NewClusterIssuerLister(issuerIndexer),
+		issuerIndexer:            issuerIndexer,
+		secretLister:             corelisters.NewSecretLister(secretsInformer.GetIndexer()),
+	}
 
-	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.secretDeleted})
-	ctrl.secretInformerSynced = secretsInformer.HasSynced
-	ctrl.secretLister = corelisters.NewSecretLister(secretsInformer.GetIndexer())
+	ctrl, err := framework.NewBuilder(ControllerName).
+		For(issuersInformer).
+		WatchesFiltered(secretsInformer, c.ownerSecretKeys, newSecretFilter(clusterResourceNamespace, c.secretReferencedByIssuer)).
+		Sync(c.sync).
+		Complete()
+	if err != nil {
+		// Only reachable if the wiring above is incomplete.
+		panic(err)
+	}
+	c.ctrl = ctrl
 
-	return ctrl
+	return c
 }
 
-// TODO: replace with generic handleObjet function (like Navigator)
-func (c *Controller) secretDeleted(obj interface{}) {
-	var secret *corev1.Secret
-	var ok bool
-	secret, ok = obj.(*corev1.Secret)
+// secretNameIndex indexes ClusterIssuers by the name of the Secret their
+// IssuerConfig references, so issuersForSecret is an O(1) indexer
+// lookup rather than a scan over every ClusterIssuer in the cluster.
+const secretNameIndex = "secretName"
+
+func indexIssuersBySecretName(obj interface{}) ([]string, error) {
+	iss, ok := obj.(*cmapi.ClusterIssuer)
 	if !ok {
-		runtime.HandleError(fmt.Errorf("Object was not a Secret object %#v", obj))
-		return
+		return nil, nil
 	}
-	issuers, err := c.issuersForSecret(secret)
-	if err != nil {
-		runtime.HandleError(fmt.Errorf("Error looking up issuers observing Secret: %s/%s", secret.Namespace, secret.Name))
-		return
+	return secretNamesReferencedBy(iss), nil
+}
+
+// secretNamesReferencedBy returns the names of every Secret iss's
+// IssuerConfig references, across every issuer backend.
+func secretNamesReferencedBy(iss *cmapi.ClusterIssuer) []string {
+	var names []string
+	if acme := iss.Spec.ACME; acme != nil && acme.PrivateKey.Name != "" {
+		names = append(names, acme.PrivateKey.Name)
 	}
-	for _, iss := range issuers {
-		key, err := keyFunc(iss)
-		if err != nil {
-			runtime.HandleError(err)
-			continue
+	if ca := iss.Spec.CA; ca != nil && ca.SecretName != "" {
+		names = append(names, ca.SecretName)
+	}
+	if vault := iss.Spec.Vault; vault != nil {
+		if ref := vault.Auth.TokenSecretRef; ref != nil && ref.Name != "" {
+			names = append(names, ref.Name)
+		}
+		if ref := vault.Auth.AppRole.SecretRef; ref.Name != "" {
+			names = append(names, ref.Name)
 		}
-		c.queue.Add(key)
 	}
+	return names
 }
 
-func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
-	glog.V(4).Infof("Starting %s control loop", ControllerName)
-	// wait for all the informer caches we depend on are synced
-	if !cache.WaitForCacheSync(stopCh, c.issuerInformerSynced, c.secretInformerSynced) {
-		// TODO: replace with Errorf call to glog
-		return fmt.Errorf("error waiting for informer caches to sync")
+// issuersForSecret returns the live ClusterIssuers that reference
+// secret, via secretNameIndex.
+func (c *Controller) issuersForSecret(secret *corev1.Secret) ([]*cmapi.ClusterIssuer, error) {
+	objs, err := c.issuerIndexer.ByIndex(secretNameIndex, secret.Name)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := 0; i < workers; i++ {
-		c.workerWg.Add(1)
-		// TODO (@munnerz): make time.Second duration configurable
-		go wait.Until(func() { c.worker(stopCh) }, time.Second, stopCh)
+	issuers := make([]*cmapi.ClusterIssuer, 0, len(objs))
+	for _, obj := range objs {
+		issuers = append(issuers, obj.(*cmapi.ClusterIssuer))
 	}
-	<-stopCh
-	glog.V(4).Infof("Shutting down queue as workqueue signaled shutdown")
-	c.queue.ShutDown()
-	glog.V(4).Infof("Waiting for workers to exit...")
-	c.workerWg.Wait()
-	glog.V(4).Infof("Workers exited.")
-	return nil
+	return issuers, nil
 }
 
-func (c *Controller) worker(stopCh <-chan struct{}) {
-	defer c.workerWg.Done()
-	log.Printf("starting worker")
-	for {
-		obj, shutdown := c.queue.Get()
-		if shutdown {
-			break
-		}
+// issuersForSecretKeys resolves a Secret to the keys of the ClusterIssuers
+// that reference it, via secretNameIndex. ownerSecretKeys, the ParentFunc
+// registered for the secrets informer, falls back to it for Secrets that
+// carry no ClusterIssuer owner reference.
+func (c *Controller) issuersForSecretKeys(obj interface{}) []cache.ObjectName {
+	logger := klog.Background().WithValues("controller", ControllerName)
 
-		err := func(obj interface{}) error {
-			defer c.queue.Done(obj)
-			var key string
-			var ok bool
-			if key, ok = obj.(string); !ok {
-				runtime.HandleError(fmt.Errorf("expected string in workqueue but got %T", obj))
-				return nil
-			}
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			ctx = util.ContextWithStopCh(ctx, stopCh)
-			glog.V(6).Infof("%s controller: syncing item '%s'", ControllerName, key)
-			if err := c.syncHandler(ctx, key); err != nil {
-				glog.V(4).Infof("%s controller: error syncing item '%s': %s", ControllerName, key, err.Error())
-				return err
-			}
-			glog.V(4).Infof("%s controller: synced item '%s'", ControllerName, key)
-			c.queue.Forget(obj)
-			return nil
-		}(obj)
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		err := fmt.Errorf("object was not a Secret object: %#v", obj)
+		logger.Error(err, "object was not a Secret object", "object", obj)
+		runtime.HandleError(err)
+		return nil
+	}
+	logger = logger.WithValues("namespace", secret.Namespace, "secret", secret.Name)
 
-		if err != nil {
-			log.Printf("requeuing item due to error processing: %s", err.Error())
-			c.queue.AddRateLimited(obj)
-			continue
-		}
+	issuers, err := c.issuersForSecret(secret)
+	if err != nil {
+		logger.Error(err, "error looking up issuers observing Secret")
+		runtime.HandleError(err)
+		return nil
+	}
 
-		log.Printf("finished processing work item")
+	keys := make([]cache.ObjectName, 0, len(issuers))
+	for _, iss := range issuers {
+		keys = append(keys, cache.NewObjectName("", iss.Name))
 	}
-	log.Printf("exiting worker loop")
+	return keys
 }
 
-func (c *Controller) processNextWorkItem(ctx context.Context, key string) error {
-	_, name, err := cache.SplitMetaNamespaceKey(key)
+// secretReferencedByIssuer reports whether secret is observed by any
+// live ClusterIssuer. It backs the secrets informer's filter so deletes
+// and writes to Secrets no ClusterIssuer cares about never reach the
+// queue.
+func (c *Controller) secretReferencedByIssuer(secret *corev1.Secret) bool {
+	issuers, err := c.issuersForSecret(secret)
 	if err != nil {
-		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
-		return nil
+		klog.Background().WithValues(
+			"controller", ControllerName,
+			"namespace", secret.Namespace,
+			"secret", secret.Name,
+		).Error(err, "error looking up issuers observing Secret")
+		runtime.HandleError(err)
+		return false
 	}
+	return len(issuers) > 0
+}
 
-	issuer, err := c.issuerLister.Get(name)
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	ctx := util.ContextWithStopCh(context.Background(), stopCh)
+	return c.ctrl.Run(ctx, workers)
+}
+
+// sync is the framework.Syncer for ClusterIssuers: it resolves key
+// against the issuer lister and hands the ClusterIssuer off to Sync.
+func (c *Controller) sync(ctx context.Context, key cache.ObjectName) error {
+	_, name := key.Parts()
+	ctx = klog.NewContext(ctx, klog.FromContext(ctx).WithValues("key", key.String()))
+	logger := klog.FromContext(ctx)
 
+	issuer, err := c.issuerLister.Get(name)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
-			runtime.HandleError(fmt.Errorf("issuer '%s' in work queue no longer exists", key))
+			logger.Error(err, "issuer in work queue no longer exists")
+			runtime.HandleError(err)
 			return nil
 		}
 
 		return err
 	}
 
+	if err := c.stampSecretOwners(ctx, issuer); err != nil {
+		logger.Error(err, "error stamping owner reference onto secret")
+		runtime.HandleError(err)
+		return err
+	}
+
 	return c.Sync(ctx, issuer)
 }
 
-var keyFunc = controllerpkg.KeyFunc
-
 const (
 	ControllerName = "clusterissuers"
+
+	// FieldManager identifies this controller as the field owner on
+	// ClusterIssuer status patches, for when those move to server-side
+	// apply.
+	FieldManager = "cert-manager-clusterissuers"
 )
 
 func init() {
@@ -0,0 +1,68 @@
+package issuers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+func TestSecretNamesReferencedBy(t *testing.T) {
+	tests := map[string]struct {
+		spec  cmapi.IssuerSpec
+		names []string
+	}{
+		"acme": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				ACME: &cmapi.ACMEIssuer{PrivateKey: cmapi.SecretKeySelector{Name: "acme-key"}},
+			}},
+			names: []string{"acme-key"},
+		},
+		"ca": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				CA: &cmapi.CAIssuer{SecretName: "ca-secret"},
+			}},
+			names: []string{"ca-secret"},
+		},
+		"vault token auth": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				Vault: &cmapi.VaultIssuer{Auth: cmapi.VaultAuth{
+					TokenSecretRef: &cmapi.SecretKeySelector{Name: "vault-token"},
+				}},
+			}},
+			names: []string{"vault-token"},
+		},
+		"vault approle auth": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				Vault: &cmapi.VaultIssuer{Auth: cmapi.VaultAuth{
+					AppRole: cmapi.VaultAppRole{SecretRef: cmapi.SecretKeySelector{Name: "vault-approle"}},
+				}},
+			}},
+			names: []string{"vault-approle"},
+		},
+		"no config": {
+			spec:  cmapi.IssuerSpec{},
+			names: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := &cmapi.ClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "issuer"},
+				Spec:       test.spec,
+			}
+
+			got := secretNamesReferencedBy(iss)
+			if len(got) != len(test.names) {
+				t.Fatalf("expected %v, got %v", test.names, got)
+			}
+			for i, n := range test.names {
+				if got[i] != n {
+					t.Fatalf("expected %v, got %v", test.names, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package issuers
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	controllerpkg "github.com/jetstack-experimental/cert-manager/pkg/controller"
+)
+
+// secretFilter is a controllerpkg.Filter for the secrets informer: it
+// accepts only Secrets in namespace that changed and are referenced by
+// a live ClusterIssuer.
+type secretFilter struct {
+	namespace    string
+	referencedBy func(secret *corev1.Secret) bool
+}
+
+func newSecretFilter(namespace string, referencedBy func(secret *corev1.Secret) bool) controllerpkg.Filter {
+	return &secretFilter{namespace: namespace, referencedBy: referencedBy}
+}
+
+func (f *secretFilter) Add(obj interface{}) bool {
+	return f.accepts(obj)
+}
+
+func (f *secretFilter) Delete(obj interface{}) bool {
+	return f.accepts(obj)
+}
+
+func (f *secretFilter) Update(oldObj, newObj interface{}) bool {
+	if !f.accepts(newObj) {
+		return false
+	}
+
+	oldSecret, ok := oldObj.(*corev1.Secret)
+	if !ok {
+		return true
+	}
+	newSecret := newObj.(*corev1.Secret)
+
+	if oldSecret.ResourceVersion == newSecret.ResourceVersion {
+		return false
+	}
+
+	return oldSecret.Type != newSecret.Type || !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+}
+
+func (f *secretFilter) accepts(obj interface{}) bool {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return false
+		}
+		secret, ok = tombstone.Obj.(*corev1.Secret)
+		if !ok {
+			return false
+		}
+	}
+
+	if secret.Namespace != f.namespace {
+		return false
+	}
+
+	return f.referencedBy == nil || f.referencedBy(secret)
+}
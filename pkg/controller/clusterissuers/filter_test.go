@@ -0,0 +1,74 @@
+package issuers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretFilterRejectsOtherNamespace(t *testing.T) {
+	f := newSecretFilter("ns-a", nil)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "s"}}
+
+	if f.Add(secret) {
+		t.Error("expected secret in a different namespace to be rejected")
+	}
+}
+
+func TestSecretFilterHonoursReferencedBy(t *testing.T) {
+	f := newSecretFilter("ns-a", func(secret *corev1.Secret) bool { return secret.Name == "referenced" })
+
+	referenced := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "referenced"}}
+	unreferenced := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "unreferenced"}}
+
+	if !f.Add(referenced) {
+		t.Error("expected a referenced secret to be accepted")
+	}
+	if f.Add(unreferenced) {
+		t.Error("expected an unreferenced secret to be rejected")
+	}
+}
+
+func TestSecretFilterUpdateRejectsNoopResync(t *testing.T) {
+	f := newSecretFilter("ns-a", nil)
+	old := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "s", ResourceVersion: "1"},
+		Data:       map[string][]byte{"tls.crt": []byte("a")},
+	}
+	same := old.DeepCopy()
+
+	if f.Update(old, same) {
+		t.Error("expected a resync with an unchanged ResourceVersion to be rejected")
+	}
+}
+
+func TestSecretFilterUpdateAcceptsDataChange(t *testing.T) {
+	f := newSecretFilter("ns-a", nil)
+	old := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "s", ResourceVersion: "1"},
+		Data:       map[string][]byte{"tls.crt": []byte("a")},
+	}
+	updated := old.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Data["tls.crt"] = []byte("b")
+
+	if !f.Update(old, updated) {
+		t.Error("expected a Secret data change to be accepted")
+	}
+}
+
+func TestSecretFilterUpdateAcceptsTypeChange(t *testing.T) {
+	f := newSecretFilter("ns-a", nil)
+	old := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "s", ResourceVersion: "1"},
+		Type:       corev1.SecretTypeOpaque,
+	}
+	updated := old.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Type = corev1.SecretTypeTLS
+
+	if !f.Update(old, updated) {
+		t.Error("expected a Secret type change to be accepted")
+	}
+}
@@ -0,0 +1,78 @@
+package issuers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	controllerpkg "github.com/jetstack-experimental/cert-manager/pkg/controller"
+)
+
+// clusterIssuerKind and clusterIssuerAPIVersion identify ClusterIssuer
+// as an owner-reference target for the Secrets stampSecretOwners stamps.
+const clusterIssuerKind = "ClusterIssuer"
+
+var clusterIssuerAPIVersion = certmanager.GroupName + "/v1alpha1"
+
+// ownerSecretKeys is the ParentFunc registered for the secrets informer.
+// It resolves a Secret's owning ClusterIssuer in O(1) from its
+// OwnerReferences, falling back to the indexer-backed issuersForSecretKeys
+// for Secrets stampSecretOwners hasn't caught up with yet.
+func (c *Controller) ownerSecretKeys(obj interface{}) []cache.ObjectName {
+	if keys := controllerpkg.EnqueueOwner(clusterIssuerAPIVersion, clusterIssuerKind)(obj); len(keys) > 0 {
+		return keys
+	}
+	return c.issuersForSecretKeys(obj)
+}
+
+// stampSecretOwners stamps iss as a (non-controller) owner reference on
+// every Secret it references that doesn't already carry one, so future
+// events on that Secret resolve back to iss via ownerSecretKeys in O(1)
+// instead of the issuersForSecret fallback. It isn't a controller ref,
+// since a Secret can be referenced by more than one ClusterIssuer (e.g.
+// a shared CA bundle).
+func (c *Controller) stampSecretOwners(ctx context.Context, iss *cmapi.ClusterIssuer) error {
+	logger := klog.FromContext(ctx)
+
+	for _, name := range secretNamesReferencedBy(iss) {
+		secret, err := c.secretLister.Secrets(c.clusterResourceNamespace).Get(name)
+		if k8sErrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if hasOwnerRef(secret, iss.Name) {
+			continue
+		}
+
+		patched := secret.DeepCopy()
+		patched.OwnerReferences = append(patched.OwnerReferences, metav1.OwnerReference{
+			APIVersion: clusterIssuerAPIVersion,
+			Kind:       clusterIssuerKind,
+			Name:       iss.Name,
+			UID:        iss.UID,
+		})
+		if _, err := c.client.CoreV1().Secrets(c.clusterResourceNamespace).Update(ctx, patched, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		logger.V(4).Info("stamped owner reference onto secret", "secret", name)
+	}
+
+	return nil
+}
+
+func hasOwnerRef(secret *corev1.Secret, issuerName string) bool {
+	for _, ref := range secret.OwnerReferences {
+		if ref.APIVersion == clusterIssuerAPIVersion && ref.Kind == clusterIssuerKind && ref.Name == issuerName {
+			return true
+		}
+	}
+	return false
+}
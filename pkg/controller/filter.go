@@ -0,0 +1,47 @@
+package controller
+
+// Filter is a predicate evaluated against informer events before they
+// are handed to a QueuingEventHandler or BlockingEventHandler. It
+// mirrors the Add/Update/Delete shape of cache.ResourceEventHandler so
+// controllers can reject events - e.g. ones outside a namespace they
+// care about, or that didn't actually change anything - before paying
+// for a resync.
+type Filter interface {
+	// Add reports whether an Add event for obj should be handled.
+	Add(obj interface{}) bool
+	// Update reports whether an Update event from oldObj to newObj
+	// should be handled.
+	Update(oldObj, newObj interface{}) bool
+	// Delete reports whether a Delete event for obj should be handled.
+	Delete(obj interface{}) bool
+}
+
+// FilterFuncs is a Filter built from individual functions, for callers
+// that don't need a stateful implementation. A nil func accepts every
+// event of that kind.
+type FilterFuncs struct {
+	AddFunc    func(obj interface{}) bool
+	UpdateFunc func(oldObj, newObj interface{}) bool
+	DeleteFunc func(obj interface{}) bool
+}
+
+func (f FilterFuncs) Add(obj interface{}) bool {
+	if f.AddFunc == nil {
+		return true
+	}
+	return f.AddFunc(obj)
+}
+
+func (f FilterFuncs) Update(oldObj, newObj interface{}) bool {
+	if f.UpdateFunc == nil {
+		return true
+	}
+	return f.UpdateFunc(oldObj, newObj)
+}
+
+func (f FilterFuncs) Delete(obj interface{}) bool {
+	if f.DeleteFunc == nil {
+		return true
+	}
+	return f.DeleteFunc(obj)
+}
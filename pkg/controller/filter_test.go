@@ -0,0 +1,41 @@
+package controller
+
+import "testing"
+
+func TestFilterFuncsDefaultsToAccept(t *testing.T) {
+	f := FilterFuncs{}
+
+	if !f.Add("obj") {
+		t.Error("Add: expected true when AddFunc is nil")
+	}
+	if !f.Update("old", "new") {
+		t.Error("Update: expected true when UpdateFunc is nil")
+	}
+	if !f.Delete("obj") {
+		t.Error("Delete: expected true when DeleteFunc is nil")
+	}
+}
+
+func TestFilterFuncsDelegates(t *testing.T) {
+	f := FilterFuncs{
+		AddFunc:    func(obj interface{}) bool { return obj == "accept" },
+		UpdateFunc: func(oldObj, newObj interface{}) bool { return newObj == "accept" },
+		DeleteFunc: func(obj interface{}) bool { return obj == "accept" },
+	}
+
+	if f.Add("reject") {
+		t.Error("Add: expected AddFunc result to be honoured")
+	}
+	if !f.Add("accept") {
+		t.Error("Add: expected AddFunc result to be honoured")
+	}
+	if f.Update("accept", "reject") {
+		t.Error("Update: expected UpdateFunc to see newObj")
+	}
+	if !f.Update("reject", "accept") {
+		t.Error("Update: expected UpdateFunc to see newObj")
+	}
+	if f.Delete("reject") {
+		t.Error("Delete: expected DeleteFunc result to be honoured")
+	}
+}
@@ -0,0 +1,204 @@
+// Package framework is a reusable base for informer-driven controllers:
+// workqueue, worker pool, cache-sync gating and secondary-informer
+// fan-out.
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	controllerpkg "github.com/jetstack-experimental/cert-manager/pkg/controller"
+)
+
+// Controller is the interface the controller-manager starts and stops
+// controllers through.
+type Controller interface {
+	// Name returns the name the controller was registered under.
+	Name() string
+	// Run starts up to workers worker goroutines and blocks until ctx is
+	// done.
+	Run(ctx context.Context, workers int) error
+}
+
+// Syncer reconciles the primary object identified by key. Returning an
+// error causes key to be requeued with backoff.
+type Syncer func(ctx context.Context, key cache.ObjectName) error
+
+// ParentFunc maps a secondary object observed on a Watches informer to
+// the primary-object keys that should be resynced as a result.
+type ParentFunc func(obj interface{}) []cache.ObjectName
+
+type secondarySource struct {
+	informer   cache.SharedIndexInformer
+	parentFunc ParentFunc
+	filter     controllerpkg.Filter
+}
+
+// Builder assembles a Controller out of a primary informer, any number
+// of secondary informers and a Syncer.
+type Builder struct {
+	name string
+
+	primary     cache.SharedIndexInformer
+	secondaries []secondarySource
+
+	syncer Syncer
+}
+
+// NewBuilder starts building a controller registered under name. name is
+// also used as the workqueue name, so it shows up in the workqueue_*
+// metrics.
+func NewBuilder(name string) *Builder {
+	return &Builder{name: name}
+}
+
+// For registers the primary informer. Add/Update/Delete events on it
+// enqueue the changed object's own key.
+func (b *Builder) For(informer cache.SharedIndexInformer) *Builder {
+	b.primary = informer
+	return b
+}
+
+// Watches registers a secondary informer. On every event, parentFunc is
+// used to translate the observed object into the primary keys that
+// should be resynced as a result.
+func (b *Builder) Watches(informer cache.SharedIndexInformer, parentFunc ParentFunc) *Builder {
+	return b.WatchesFiltered(informer, parentFunc, nil)
+}
+
+// WatchesFiltered is like Watches, but events are first passed through
+// filter; events filter rejects never reach parentFunc.
+func (b *Builder) WatchesFiltered(informer cache.SharedIndexInformer, parentFunc ParentFunc, filter controllerpkg.Filter) *Builder {
+	b.secondaries = append(b.secondaries, secondarySource{informer: informer, parentFunc: parentFunc, filter: filter})
+	return b
+}
+
+// Sync registers the function used to reconcile primary objects.
+func (b *Builder) Sync(syncer Syncer) *Builder {
+	b.syncer = syncer
+	return b
+}
+
+// Complete validates the builder and wires up event handlers, returning
+// the assembled Controller.
+func (b *Builder) Complete() (Controller, error) {
+	if b.primary == nil {
+		return nil, fmt.Errorf("%s: no primary informer registered with For()", b.name)
+	}
+	if b.syncer == nil {
+		return nil, fmt.Errorf("%s: no Syncer registered with Sync()", b.name)
+	}
+
+	c := &controller{
+		name:   b.name,
+		queue:  newRateLimitingQueue(b.name),
+		syncer: b.syncer,
+		synced: []cache.InformerSynced{b.primary.HasSynced},
+	}
+
+	b.primary.AddEventHandler(&controllerpkg.ObjectNameEventHandler{Queue: c.queue})
+
+	for _, s := range b.secondaries {
+		c.synced = append(c.synced, s.informer.HasSynced)
+		s.informer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: c.enqueueParentsOf(s.parentFunc),
+			Filter:   s.filter,
+		})
+	}
+
+	return c, nil
+}
+
+// newRateLimitingQueue builds the composite rate limiter shared by every
+// framework controller: per-item exponential backoff, bounded overall by
+// a token-bucket limiter.
+func newRateLimitingQueue(name string) workqueue.TypedRateLimitingInterface[cache.ObjectName] {
+	limiter := workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[cache.ObjectName](5*time.Millisecond, 1000*time.Second),
+		&workqueue.TypedBucketRateLimiter[cache.ObjectName]{Limiter: rate.NewLimiter(rate.Limit(50), 300)},
+	)
+	return workqueue.NewTypedRateLimitingQueueWithConfig(limiter, workqueue.TypedRateLimitingQueueConfig[cache.ObjectName]{Name: name})
+}
+
+// controller is the Controller implementation returned by Builder.
+type controller struct {
+	name string
+
+	queue  workqueue.TypedRateLimitingInterface[cache.ObjectName]
+	synced []cache.InformerSynced
+	syncer Syncer
+
+	workerWg sync.WaitGroup
+}
+
+func (c *controller) Name() string {
+	return c.name
+}
+
+// enqueueParentsOf returns an informer handler func that resolves obj's
+// parents via parentFunc and adds each of them to the queue.
+func (c *controller) enqueueParentsOf(parentFunc ParentFunc) func(obj interface{}) {
+	return func(obj interface{}) {
+		for _, key := range parentFunc(obj) {
+			c.queue.Add(key)
+		}
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) error {
+	logger := klog.FromContext(ctx).WithValues("controller", c.name)
+	ctx = klog.NewContext(ctx, logger)
+	stopCh := ctx.Done()
+
+	logger.V(4).Info("Starting control loop")
+	if !cache.WaitForCacheSync(stopCh, c.synced...) {
+		return fmt.Errorf("error waiting for %s informer caches to sync", c.name)
+	}
+
+	for i := 0; i < workers; i++ {
+		c.workerWg.Add(1)
+		go wait.Until(func() { c.worker(ctx) }, time.Second, stopCh)
+	}
+	<-stopCh
+	logger.V(4).Info("Shutting down queue as workqueue signaled shutdown")
+	c.queue.ShutDown()
+	logger.V(4).Info("Waiting for workers to exit...")
+	c.workerWg.Wait()
+	logger.V(4).Info("Workers exited")
+	return nil
+}
+
+func (c *controller) worker(ctx context.Context) {
+	defer c.workerWg.Done()
+	logger := klog.FromContext(ctx)
+	for {
+		key, shutdown := c.queue.Get()
+		if shutdown {
+			return
+		}
+
+		func(key cache.ObjectName) {
+			defer c.queue.Done(key)
+
+			itemLogger := logger.WithValues("key", key.String())
+			itemCtx := klog.NewContext(ctx, itemLogger)
+
+			itemLogger.V(6).Info("syncing item")
+			if err := c.syncer(itemCtx, key); err != nil {
+				itemLogger.V(4).Info("error syncing item", "err", err.Error())
+				c.queue.AddRateLimited(key)
+				return
+			}
+			itemLogger.V(4).Info("synced item")
+			c.queue.Forget(key)
+		}(key)
+	}
+}
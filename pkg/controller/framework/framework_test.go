@@ -0,0 +1,23 @@
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestBuilderCompleteRequiresPrimaryInformer(t *testing.T) {
+	_, err := NewBuilder("test").Sync(func(ctx context.Context, key cache.ObjectName) error { return nil }).Complete()
+	if err == nil {
+		t.Fatal("expected error when no primary informer is registered with For()")
+	}
+}
+
+func TestBuilderCompleteRequiresSyncer(t *testing.T) {
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, nil, 0, cache.Indexers{})
+	_, err := NewBuilder("test").For(informer).Complete()
+	if err == nil {
+		t.Fatal("expected error when no Syncer is registered with Sync()")
+	}
+}
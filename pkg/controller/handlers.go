@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// KeyFunc is the standard key function used to derive workqueue keys
+// across cert-manager's controllers.
+var KeyFunc = cache.DeletionHandlingMetaNamespaceKeyFunc
+
+// QueuingEventHandler is a cache.ResourceEventHandler that enqueues the
+// key of the affected object onto Queue for every event it observes. If
+// Filter is set, events it rejects are not enqueued.
+type QueuingEventHandler struct {
+	Queue  workqueue.RateLimitingInterface
+	Filter Filter
+}
+
+func (q *QueuingEventHandler) OnAdd(obj interface{}) {
+	if q.Filter != nil && !q.Filter.Add(obj) {
+		return
+	}
+	q.enqueue(obj)
+}
+
+func (q *QueuingEventHandler) OnUpdate(old, new interface{}) {
+	if q.Filter != nil && !q.Filter.Update(old, new) {
+		return
+	}
+	q.enqueue(new)
+}
+
+func (q *QueuingEventHandler) OnDelete(obj interface{}) {
+	if q.Filter != nil && !q.Filter.Delete(obj) {
+		return
+	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	q.enqueue(obj)
+}
+
+func (q *QueuingEventHandler) enqueue(obj interface{}) {
+	key, err := KeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	q.Queue.Add(key)
+}
+
+// ObjectNameEventHandler is the cache.ObjectName-typed counterpart to
+// QueuingEventHandler, for workqueue.TypedRateLimitingInterface[cache.ObjectName]
+// queues. If Filter is set, events it rejects are not enqueued.
+type ObjectNameEventHandler struct {
+	Queue  workqueue.TypedRateLimitingInterface[cache.ObjectName]
+	Filter Filter
+}
+
+func (o *ObjectNameEventHandler) OnAdd(obj interface{}) {
+	if o.Filter != nil && !o.Filter.Add(obj) {
+		return
+	}
+	o.enqueue(obj)
+}
+
+func (o *ObjectNameEventHandler) OnUpdate(old, new interface{}) {
+	if o.Filter != nil && !o.Filter.Update(old, new) {
+		return
+	}
+	o.enqueue(new)
+}
+
+func (o *ObjectNameEventHandler) OnDelete(obj interface{}) {
+	if o.Filter != nil && !o.Filter.Delete(obj) {
+		return
+	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o.enqueue(obj)
+}
+
+func (o *ObjectNameEventHandler) enqueue(obj interface{}) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	o.Queue.Add(cache.MetaObjectToName(accessor))
+}
+
+// BlockingEventHandler is a cache.ResourceEventHandler that
+// synchronously invokes WorkFunc with the affected object. If Filter is
+// set, events it rejects are dropped before WorkFunc is called.
+type BlockingEventHandler struct {
+	WorkFunc func(obj interface{})
+	Filter   Filter
+}
+
+func (b *BlockingEventHandler) OnAdd(obj interface{}) {
+	if b.Filter != nil && !b.Filter.Add(obj) {
+		return
+	}
+	b.WorkFunc(obj)
+}
+
+func (b *BlockingEventHandler) OnUpdate(old, new interface{}) {
+	if b.Filter != nil && !b.Filter.Update(old, new) {
+		return
+	}
+	b.WorkFunc(new)
+}
+
+func (b *BlockingEventHandler) OnDelete(obj interface{}) {
+	if b.Filter != nil && !b.Filter.Delete(obj) {
+		return
+	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	b.WorkFunc(obj)
+}
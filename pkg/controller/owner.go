@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EnqueueOwner returns a function, suitable for use as a
+// framework.ParentFunc, that resolves obj's owners matching
+// apiVersion/kind directly from its OwnerReferences - the same
+// in-memory metadata controller-runtime's EnqueueRequestForOwner reads.
+// Unlike a lister-scan ParentFunc, this is O(1) regardless of how many
+// owning objects exist in the cluster. It returns no keys for objects
+// that don't carry a matching owner reference, so callers should pair it
+// with a lister-scan fallback for objects that predate the
+// owner-reference convention or that no writer has stamped yet.
+// Every key it returns has an empty namespace, so it names cluster-scoped
+// owners only; for an owner of a namespaced kind the key does not carry
+// obj's namespace.
+func EnqueueOwner(apiVersion, kind string) func(obj interface{}) []cache.ObjectName {
+	return func(obj interface{}) []cache.ObjectName {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil
+		}
+
+		var keys []cache.ObjectName
+		for _, ref := range accessor.GetOwnerReferences() {
+			if ref.APIVersion != apiVersion || ref.Kind != kind {
+				continue
+			}
+			keys = append(keys, cache.NewObjectName("", ref.Name))
+		}
+		return keys
+	}
+}